@@ -18,10 +18,61 @@ type Buffer struct {
 	Buf  []byte
 	head uint64 // Number of bytes ever written
 	tail uint64 // Number of bytes ever read
+
+	// mask is cap-1 and pow2 is true only for a Buffer built by NewBufferPow2 (a Buffer from
+	// NewBuffer or a struct literal uses the modulo path even if len(Buf) is a power of two).
+	// This lets index() use a bitwise AND instead of a 64-bit DIV for the common case.
+	mask uint64
+	pow2 bool
+
+	// dataReady/spaceReady back ReadContext/WriteContext. They're only populated by NewBuffer and
+	// NewBufferPow2—a Buffer built as a plain struct literal has nil channels, so Read/Write's
+	// notify calls are harmless no-ops (notify is nil-channel-safe) and ReadContext/WriteContext
+	// on such a Buffer only ever return via ctx cancellation, never via a signal. Populating them
+	// at construction, rather than lazily on first Context call, means Read/Write never need to
+	// synchronize with a concurrent initialization: the channels are visible to every goroutine
+	// that obtains the Buffer, since that handoff itself (a go statement, a channel send, etc.)
+	// already establishes happens-before.
+	dataReady  chan struct{} // signalled (non-blocking) when Write moves bytes; used by ReadContext
+	spaceReady chan struct{} // signalled (non-blocking) when Read moves bytes; used by WriteContext
 }
 
 func NewBuffer(n int) *Buffer {
-	return &Buffer{Buf: make([]byte, n)}
+	return &Buffer{Buf: make([]byte, n), dataReady: make(chan struct{}, 1), spaceReady: make(chan struct{}, 1)}
+}
+
+// NewBufferPow2 returns a Buffer whose capacity is nMin rounded up to the next power of two,
+// so that Read/Write can index it with a bitwise AND instead of a modulo.
+func NewBufferPow2(nMin int) *Buffer {
+	n := nextPow2(nMin)
+	return &Buffer{
+		Buf: make([]byte, n), mask: uint64(n - 1), pow2: true,
+		dataReady: make(chan struct{}, 1), spaceReady: make(chan struct{}, 1),
+	}
+}
+
+// nextPow2 rounds n up to the next power of two (n itself, if already one). Any n <= 1 gives 1.
+func nextPow2(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	n--
+	n |= n >> 1
+	n |= n >> 2
+	n |= n >> 4
+	n |= n >> 8
+	n |= n >> 16
+	n |= n >> 32
+	return n + 1
+}
+
+// index maps a byte offset (head or tail) to a position within Buf, using a bitwise AND in
+// place of the modulo when Buf's length is a power of two.
+func (b *Buffer) index(x uint64) uint64 {
+	if b.pow2 {
+		return x & b.mask
+	}
+	return x % uint64(len(b.Buf))
 }
 
 // Read reads up to len(p) bytes into p. It returns the number of bytes read and any error
@@ -52,12 +103,15 @@ func (b *Buffer) Read(p []byte) (n int, err error) {
 		dest = p[:]
 	}
 
-	bOffset := int(tail % uint64(len(b.Buf)))
+	bOffset := int(b.index(tail))
 	n = copy(dest, b.Buf[bOffset:])
 	// Noop (n=0) if all the bytes were copied above
 	n += copy(dest[n:], b.Buf[:len(dest)-n])
 
 	atomic.AddUint64(&b.tail, uint64(n))
+	if n > 0 {
+		notify(b.spaceReady)
+	}
 	return
 }
 
@@ -86,15 +140,120 @@ func (b *Buffer) Write(p []byte) (n int, err error) {
 		src = p[:]
 	}
 
-	bOffset := int(head % uint64(len(b.Buf)))
+	bOffset := int(b.index(head))
 	n = copy(b.Buf[bOffset:], src)
 	// Noop (n=0) if all bytes were copied above
 	n += copy(b.Buf[:len(src)-n], src[n:])
 
 	atomic.AddUint64(&b.head, uint64(n))
+	if n > 0 {
+		notify(b.dataReady)
+	}
 	return
 }
 
+// WriteTo writes data to w until the buffer is empty or an error occurs. It implements
+// io.WriterTo, writing directly out of the buffer's readable regions so no intermediate copy
+// is needed.
+//
+// Returns the number of bytes written and the first error encountered from w, if any. Unlike
+// Read, WriteTo does not return io.EOF when the buffer is empty—a nil error means w has
+// consumed everything that was available.
+//
+// Only one goroutine should call WriteTo (or Read) at a time—WriteTo is not thread safe (WriteTo
+// and Write can be called concurrently however).
+func (b *Buffer) WriteTo(w io.Writer) (n int64, err error) {
+	for {
+		tail := atomic.LoadUint64(&b.tail)
+		length := atomic.LoadUint64(&b.head) - tail
+
+		if length == 0 {
+			return n, nil
+		}
+
+		// Contiguous readable region starting at tail—stops at the end of Buf if the
+		// readable bytes wrap around.
+		bOffset := int(b.index(tail))
+		end := bOffset + int(length)
+		if end > len(b.Buf) {
+			end = len(b.Buf)
+		}
+
+		nw, werr := w.Write(b.Buf[bOffset:end])
+		if nw > 0 {
+			atomic.AddUint64(&b.tail, uint64(nw))
+			n += int64(nw)
+		}
+		if werr != nil {
+			return n, werr
+		}
+		if nw < end-bOffset {
+			return n, io.ErrShortWrite
+		}
+	}
+}
+
+// ReadFrom reads data from r until r returns io.EOF or the buffer runs out of space. It
+// implements io.ReaderFrom, reading directly into the buffer's free regions so no intermediate
+// copy is needed.
+//
+// Returns the number of bytes read and n, ErrNoSpace if r still had data to give but the buffer
+// filled up first. A nil error means r reached io.EOF.
+//
+// Disambiguating those two cases once the buffer is full relies on r reporting io.EOF from a
+// zero-length Read—which the io.Reader contract merely discourages implementations from
+// skipping, it doesn't forbid it (this package's own Read is one such implementation: it
+// returns 0, nil for a zero-length p without checking whether the buffer is exhausted). So if r
+// exposes a Len() int method (as *Buffer, *OverwritingBuffer and bytes.Buffer all do),
+// ReadFrom uses that to tell the two cases apart precisely instead of guessing; otherwise it
+// falls back to the zero-length probe, which is correct for many io.Reader implementations
+// (e.g. bytes.Reader) but not guaranteed in general.
+//
+// Only one goroutine should call ReadFrom (or Write) at a time—ReadFrom is not thread safe
+// (ReadFrom and Read can be called concurrently however).
+func (b *Buffer) ReadFrom(r io.Reader) (n int64, err error) {
+	for {
+		head := atomic.LoadUint64(&b.head)
+		length := head - atomic.LoadUint64(&b.tail)
+		space := uint64(len(b.Buf)) - length
+
+		if space == 0 {
+			if lr, ok := r.(interface{ Len() int }); ok {
+				if lr.Len() == 0 {
+					return n, nil
+				}
+				return n, ErrNoSpace
+			}
+		}
+
+		// Contiguous free region starting at head—stops at the end of Buf if the free
+		// space wraps around. When space == 0 and r doesn't report its own Len(), this is
+		// an empty slice used as the best-effort io.EOF probe described above.
+		bOffset := int(b.index(head))
+		end := bOffset + int(space)
+		if end > len(b.Buf) {
+			end = len(b.Buf)
+		}
+
+		nr, rerr := r.Read(b.Buf[bOffset:end])
+		if nr > 0 {
+			atomic.AddUint64(&b.head, uint64(nr))
+			n += int64(nr)
+		}
+		if rerr != nil {
+			if rerr == io.EOF {
+				return n, nil
+			}
+			return n, rerr
+		}
+		if space == 0 {
+			// r gave back no error (and, since the slice was empty, no bytes) but still
+			// has data pending—the buffer is genuinely out of room.
+			return n, ErrNoSpace
+		}
+	}
+}
+
 // Len returns the number of bytes of the unread portion of the buffer
 //
 // Calls to Len are thread-safe, however the value returned may immediately be stale if a Read or
@@ -124,6 +283,15 @@ func (b *Buffer) Reset() {
 	atomic.StoreUint64(&b.tail, 0)
 }
 
+// notify performs a non-blocking send on ch, coalescing with any already-pending signal so
+// Read/Write never block on a slow or absent waiter.
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
 // ErrNoSpace is the error returned by Write when bytes written is < len(p) due to limited space
 // in the buffer, including when 0 bytes were written.
 var ErrNoSpace = fmt.Errorf("no space in buffer")
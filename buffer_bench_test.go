@@ -0,0 +1,21 @@
+package circular
+
+import "testing"
+
+func benchmarkReadWrite(b *testing.B, buf *Buffer) {
+	p := make([]byte, 64)
+	out := make([]byte, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = buf.Write(p)
+		_, _ = buf.Read(out)
+	}
+}
+
+func BenchmarkBuffer_ReadWrite_Modulo(b *testing.B) {
+	benchmarkReadWrite(b, NewBuffer(4096))
+}
+
+func BenchmarkBuffer_ReadWrite_Pow2(b *testing.B) {
+	benchmarkReadWrite(b, NewBufferPow2(4096))
+}
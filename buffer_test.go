@@ -313,6 +313,103 @@ func TestCircularBuffer_WraparoundPastZero(t *testing.T) {
 	}
 }
 
+func TestCircularBuffer_ReadFromAndWriteTo(t *testing.T) {
+	b := &Buffer{Buf: make([]byte, 10)}
+
+	// Wrap head/tail part way around the buffer first, so ReadFrom/WriteTo are exercised
+	// against the wraparound case, not just the simple contiguous case.
+	_, _ = b.Write(make([]byte, 6))
+	_, _ = b.Read(make([]byte, 6))
+
+	p := []byte("abcdefghij")
+	n, err := b.ReadFrom(bytes.NewReader(p))
+	if err != nil {
+		t.Errorf("failed to ReadFrom (err: %v)", err)
+	}
+	if n != int64(len(p)) {
+		t.Errorf("incorrect n (got: %d, expected: %d)", n, len(p))
+	}
+
+	n, err = b.ReadFrom(bytes.NewReader([]byte("k")))
+	if n != 0 {
+		t.Errorf("read bytes into full buffer (read %d)", n)
+	}
+	if err != ErrNoSpace {
+		t.Errorf("did not return ErrNoSpace when reading into full buffer")
+	}
+
+	var out bytes.Buffer
+	n, err = b.WriteTo(&out)
+	if err != nil {
+		t.Errorf("failed to WriteTo (err: %v)", err)
+	}
+	if n != int64(len(p)) {
+		t.Errorf("incorrect n (got: %d, expected: %d)", n, len(p))
+	}
+	if !bytes.Equal(out.Bytes(), p) {
+		t.Errorf("wrote incorrect bytes (wrote: %s, expected: %s)", out.Bytes(), p)
+	}
+
+	n, err = b.WriteTo(&out)
+	if n != 0 || err != nil {
+		t.Errorf("WriteTo on empty buffer should be a no-op (n: %d, err: %v)", n, err)
+	}
+}
+
+// A *Buffer source exposes Len(), so ReadFrom must use it to tell "source exhausted" apart from
+// "destination full" rather than guessing via a zero-length probe read—which Buffer.Read itself
+// doesn't answer (it returns 0, nil for a zero-length p regardless of its own state).
+func TestCircularBuffer_ReadFromBufferSource(t *testing.T) {
+	dst := NewBuffer(4)
+	_, _ = dst.Write([]byte("abcd"))
+	src := NewBuffer(4) // already empty
+
+	n, err := dst.ReadFrom(src)
+	if n != 0 {
+		t.Errorf("incorrect n (got: %d, expected: 0)", n)
+	}
+	if err != nil {
+		t.Errorf("expected nil error for an exhausted source, got %v", err)
+	}
+
+	_, _ = src.Write([]byte("e"))
+	n, err = dst.ReadFrom(src)
+	if n != 0 {
+		t.Errorf("incorrect n (got: %d, expected: 0)", n)
+	}
+	if err != ErrNoSpace {
+		t.Errorf("expected ErrNoSpace when source still has data, got %v", err)
+	}
+}
+
+func TestCircularBuffer_NewBufferPow2(t *testing.T) {
+	cases := []struct{ nMin, wantCap int }{
+		{0, 1},
+		{1, 1},
+		{5, 8},
+		{8, 8},
+		{9, 16},
+	}
+	for _, c := range cases {
+		b := NewBufferPow2(c.nMin)
+		if b.Cap() != c.wantCap {
+			t.Errorf("NewBufferPow2(%d).Cap() = %d, want %d", c.nMin, b.Cap(), c.wantCap)
+		}
+	}
+
+	// The pow2 fast path must agree with the modulo path across a full wraparound.
+	b := NewBufferPow2(8)
+	p := []byte("abcdefghij")
+	for i := 0; i < len(p); i++ {
+		_, _ = b.Write(p[i : i+1])
+		out := make([]byte, 1)
+		_, _ = b.Read(out)
+		if out[0] != p[i] {
+			t.Errorf("pow2 buffer read incorrect byte (got: %c, expected: %c)", out[0], p[i])
+		}
+	}
+}
+
 // Ensures that random concurrent reads and writes preserve the integrity of the data read from
 // the buffer. Can be run with -race detector.
 func TestCircularBuffer_Concurrency(t *testing.T) {
@@ -0,0 +1,59 @@
+package circular
+
+import (
+	"context"
+	"io"
+)
+
+// ReadContext behaves like Read, except that instead of returning 0, io.EOF when the buffer is
+// empty, it blocks until at least one byte can be read or ctx is cancelled.
+//
+// Returns 0, ctx.Err() if ctx is cancelled before any bytes could be read.
+//
+// b must have been constructed via NewBuffer or NewBufferPow2—a Buffer built as a plain struct
+// literal has no notification channels, so ReadContext on it only ever returns via ctx
+// cancellation, never via a Write elsewhere signalling new data.
+//
+// Only one goroutine should Read (or ReadContext) at a time—ReadContext is not thread safe (Read
+// and Write can be called concurrently however).
+func (b *Buffer) ReadContext(ctx context.Context, p []byte) (n int, err error) {
+	for {
+		n, err = b.Read(p)
+		if n > 0 || err != io.EOF {
+			return n, err
+		}
+
+		select {
+		case <-b.dataReady:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// WriteContext behaves like Write, except that instead of returning n, ErrNoSpace when the
+// buffer has no free space at all, it blocks until at least one byte can be written or ctx is
+// cancelled.
+//
+// Returns 0, ctx.Err() if ctx is cancelled before any bytes could be written.
+//
+// b must have been constructed via NewBuffer or NewBufferPow2—a Buffer built as a plain struct
+// literal has no notification channels, so WriteContext on it only ever returns via ctx
+// cancellation, never via a Read elsewhere signalling freed space.
+//
+// Only one goroutine should Write (or WriteContext) at a time—WriteContext is not thread safe
+// (Read and Write can be called concurrently however).
+func (b *Buffer) WriteContext(ctx context.Context, p []byte) (n int, err error) {
+	for {
+		n, err = b.Write(p)
+		if n > 0 || err != ErrNoSpace {
+			return n, err
+		}
+
+		select {
+		case <-b.spaceReady:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
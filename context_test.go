@@ -0,0 +1,74 @@
+package circular
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCircularBuffer_ReadContext_BlocksUntilWrite(t *testing.T) {
+	b := NewBuffer(10)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_, _ = b.Write([]byte("hi"))
+	}()
+
+	p := make([]byte, 2)
+	n, err := b.ReadContext(context.Background(), p)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if n != 2 || string(p) != "hi" {
+		t.Errorf("incorrect read (n: %d, p: %s)", n, p)
+	}
+}
+
+func TestCircularBuffer_ReadContext_CancelledContext(t *testing.T) {
+	b := NewBuffer(10)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	n, err := b.ReadContext(ctx, make([]byte, 1))
+	if n != 0 {
+		t.Errorf("expected no bytes read, got %d", n)
+	}
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestCircularBuffer_WriteContext_BlocksUntilSpace(t *testing.T) {
+	b := NewBuffer(4)
+	_, _ = b.Write([]byte("abcd"))
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_, _ = b.Read(make([]byte, 4))
+	}()
+
+	n, err := b.WriteContext(context.Background(), []byte("wxyz"))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if n != 4 {
+		t.Errorf("incorrect n (got: %d, expected: 4)", n)
+	}
+}
+
+func TestCircularBuffer_WriteContext_CancelledContext(t *testing.T) {
+	b := NewBuffer(4)
+	_, _ = b.Write([]byte("abcd"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	n, err := b.WriteContext(ctx, []byte("e"))
+	if n != 0 {
+		t.Errorf("expected no bytes written, got %d", n)
+	}
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
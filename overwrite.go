@@ -0,0 +1,135 @@
+package circular
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// OverwritingBuffer is a circular byte buffer with drop-oldest ("overwrite") semantics: Write
+// never fails due to lack of space. If p is larger than the available space, the oldest unread
+// bytes are evicted (by advancing tail) to make room, and if p itself is larger than the whole
+// buffer, only the most recent len(Buf) bytes of p are kept.
+//
+// Unlike Buffer, both Write and Read may mutate tail here—Write advances it to evict, Read
+// advances it to consume. An eviction can overwrite bytes a concurrent Read hasn't copied out
+// yet, which is a genuine data race on Buf itself (not just on head/tail) and can't be resolved
+// by retrying after the fact, as a seqlock would: Go gives no way to validate a multi-byte copy
+// was torn without already having raced on it. So Read and Write share a mutex that serializes
+// the copy into/out of Buf; head and tail remain atomics purely so Len/Space/Cap stay lock-free
+// for callers that just want a (possibly stale) snapshot.
+//
+// Instantiate with a Buf of desired length, e.g: &OverwritingBuffer{ Buf: make([]byte, 1024) }
+type OverwritingBuffer struct {
+	Buf  []byte
+	head uint64 // Number of bytes ever written
+	tail uint64 // Number of bytes ever read or evicted
+	mu   sync.Mutex
+}
+
+func NewOverwritingBuffer(n int) *OverwritingBuffer {
+	return &OverwritingBuffer{Buf: make([]byte, n)}
+}
+
+// Read reads up to len(p) bytes into p. It returns the number of bytes read and any error
+// encountered.
+//
+// Returns 0, io.EOF when the buffer is empty.
+//
+// Read may be called concurrently with Write—they're serialized internally—but only one
+// goroutine should call Read at a time.
+func (b *OverwritingBuffer) Read(p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	tail := atomic.LoadUint64(&b.tail)
+	length := atomic.LoadUint64(&b.head) - tail
+	if length == 0 {
+		return 0, io.EOF
+	}
+
+	var dest []byte
+	if uint64(len(p)) > length {
+		dest = p[:length]
+	} else {
+		dest = p[:]
+	}
+
+	bOffset := int(tail % uint64(len(b.Buf)))
+	n = copy(dest, b.Buf[bOffset:])
+	// Noop (n=0) if all the bytes were copied above
+	n += copy(dest[n:], b.Buf[:len(dest)-n])
+
+	atomic.AddUint64(&b.tail, uint64(n))
+	return n, nil
+}
+
+// Write writes all of p to the buffer, never returning ErrNoSpace. If there isn't enough free
+// space for p, the oldest unread bytes are evicted (tail is advanced) to make room; if p itself
+// is longer than len(Buf), only the most recent len(Buf) bytes of p are kept.
+//
+// Always returns n == len(p), nil.
+//
+// Write may be called concurrently with Read—they're serialized internally—but only one
+// goroutine should call Write at a time.
+func (b *OverwritingBuffer) Write(p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	src := p
+	if uint64(len(src)) > uint64(len(b.Buf)) {
+		src = src[len(src)-len(b.Buf):]
+	}
+
+	head := atomic.LoadUint64(&b.head)
+	length := head - atomic.LoadUint64(&b.tail)
+	space := uint64(len(b.Buf)) - length
+
+	if uint64(len(src)) > space {
+		shortfall := uint64(len(src)) - space
+		atomic.AddUint64(&b.tail, shortfall)
+	}
+
+	bOffset := int(head % uint64(len(b.Buf)))
+	written := copy(b.Buf[bOffset:], src)
+	// Noop (written unchanged) if all bytes were copied above
+	written += copy(b.Buf[:len(src)-written], src[written:])
+
+	atomic.AddUint64(&b.head, uint64(written))
+	return len(p), nil
+}
+
+// Len returns the number of bytes of the unread portion of the buffer
+//
+// Calls to Len are thread-safe, however the value returned may immediately be stale if a Read or
+// Write completes concurrently.
+func (b *OverwritingBuffer) Len() int {
+	return int(atomic.LoadUint64(&b.head) - atomic.LoadUint64(&b.tail))
+}
+
+// Space returns the capacity the buffer has to hold more data without evicting unread bytes.
+//
+// Calls to Space are thread-safe, however the value returned may immediately be stale if a Read or
+// Write completes concurrently.
+func (b *OverwritingBuffer) Space() int {
+	return len(b.Buf) - b.Len()
+}
+
+// Cap returns the capacity of the underlying buffer (Buf).
+func (b *OverwritingBuffer) Cap() int {
+	return len(b.Buf)
+}
+
+// Reset clears the buffer by resetting head/tail offsets.
+//
+// Calls to Reset are not thread-safe, and should not be called concurrently with Read or Write.
+func (b *OverwritingBuffer) Reset() {
+	atomic.StoreUint64(&b.head, 0)
+	atomic.StoreUint64(&b.tail, 0)
+}
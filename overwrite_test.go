@@ -0,0 +1,116 @@
+package circular
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"runtime"
+	"testing"
+)
+
+func TestOverwritingBuffer_EvictsOldestOnOverflow(t *testing.T) {
+	b := &OverwritingBuffer{Buf: make([]byte, 10)}
+
+	n, err := b.Write([]byte("abcdefghij"))
+	if err != nil {
+		t.Errorf("failed to write to buffer (err: %v)", err)
+	}
+	if n != 10 {
+		t.Errorf("incorrect n (got: %d, expected: 10)", n)
+	}
+
+	// Buffer is full; writing 3 more bytes should evict "abc" rather than failing.
+	n, err = b.Write([]byte("KLM"))
+	if err != nil {
+		t.Errorf("Write returned an error in overwrite mode (err: %v)", err)
+	}
+	if n != 3 {
+		t.Errorf("incorrect n (got: %d, expected: 3)", n)
+	}
+
+	out := make([]byte, 10)
+	n, err = b.Read(out)
+	if err != nil {
+		t.Errorf("failed to read from buffer (err: %v)", err)
+	}
+	if !bytes.Equal(out[:n], []byte("defghijKLM")) {
+		t.Errorf("read incorrect bytes (read: %s, expected: defghijKLM)", out[:n])
+	}
+}
+
+func TestOverwritingBuffer_WriteLargerThanCapacity(t *testing.T) {
+	b := &OverwritingBuffer{Buf: make([]byte, 5)}
+
+	n, err := b.Write([]byte("abcdefghij"))
+	if err != nil {
+		t.Errorf("Write returned an error in overwrite mode (err: %v)", err)
+	}
+	if n != 10 {
+		t.Errorf("incorrect n (got: %d, expected: 10)", n)
+	}
+	if b.Len() != 5 {
+		t.Errorf("incorrect len (got: %d, expected: 5)", b.Len())
+	}
+
+	out := make([]byte, 5)
+	_, _ = b.Read(out)
+	if !bytes.Equal(out, []byte("fghij")) {
+		t.Errorf("read incorrect bytes (read: %s, expected: fghij)", out)
+	}
+}
+
+// Ensures that a writer evicting oldest bytes and a reader consuming them concurrently never
+// hand back torn or stale data. Each "message" is a 4-byte counter, written and read as a unit
+// and sized so that evictions always consume whole messages—letting the reader assert the
+// stream of values it sees is strictly increasing (evictions drop messages, but never reorder or
+// corrupt them). Run with -race.
+func TestOverwritingBuffer_Concurrency(t *testing.T) {
+	const n = 200_000
+	b := &OverwritingBuffer{Buf: make([]byte, 64)} // 16 four-byte slots: heavy eviction pressure
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var rec [4]byte
+		for i := uint32(0); i < n; i++ {
+			binary.BigEndian.PutUint32(rec[:], i)
+			_, _ = b.Write(rec[:])
+		}
+	}()
+
+	producerDone := false
+	haveLast := false
+	var last uint32
+	var rec [4]byte
+	for {
+		nr, err := b.Read(rec[:])
+		if err == io.EOF {
+			if producerDone && b.Len() == 0 {
+				break
+			}
+			select {
+			case <-done:
+				producerDone = true
+			default:
+			}
+			runtime.Gosched()
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error from Read: %v", err)
+		}
+		if nr != 4 {
+			t.Fatalf("torn read: got %d bytes, want 4", nr)
+		}
+
+		v := binary.BigEndian.Uint32(rec[:])
+		if haveLast && v <= last {
+			t.Fatalf("values out of order or duplicated: got %d after %d", v, last)
+		}
+		last, haveLast = v, true
+	}
+
+	if !haveLast || last != n-1 {
+		t.Fatalf("did not observe final value (got last=%d, haveLast=%v)", last, haveLast)
+	}
+}
@@ -0,0 +1,116 @@
+package circular
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+// Peek returns, without advancing tail, the up-to-n unread bytes as two slices: the contiguous
+// run starting at the current read position, and—if the unread bytes wrap around the end of
+// Buf—the remainder continuing from the start of Buf. second is nil if no wraparound is
+// involved.
+//
+// If fewer than n bytes are available, both slices together cover only what's available and
+// err is io.EOF.
+//
+// The returned slices alias Buf directly and are invalidated by the next Read or Discard.
+//
+// Only one goroutine should call Peek (or Read/Discard) at a time—Peek is not thread safe (the
+// read side and Write/ReadFrom can be called concurrently however).
+func (b *Buffer) Peek(n int) (first, second []byte, err error) {
+	if n <= 0 {
+		return nil, nil, nil
+	}
+
+	tail := atomic.LoadUint64(&b.tail)
+	length := atomic.LoadUint64(&b.head) - tail
+	if uint64(n) > length {
+		n = int(length)
+		err = io.EOF
+	}
+	if n == 0 {
+		return nil, nil, err
+	}
+
+	bOffset := int(b.index(tail))
+	end := bOffset + n
+	if end > len(b.Buf) {
+		first = b.Buf[bOffset:]
+		second = b.Buf[:end-len(b.Buf)]
+	} else {
+		first = b.Buf[bOffset:end]
+	}
+	return
+}
+
+// Discard skips the next n unread bytes, the same as reading them into a throwaway buffer but
+// without the copy.
+//
+// If fewer than n bytes were available, Discard skips all of them and returns io.EOF along with
+// the number actually discarded.
+//
+// Only one goroutine should call Discard (or Read/Peek) at a time—Discard is not thread safe
+// (the read side and Write/ReadFrom can be called concurrently however).
+func (b *Buffer) Discard(n int) (discarded int, err error) {
+	if n <= 0 {
+		return 0, nil
+	}
+
+	length := atomic.LoadUint64(&b.head) - atomic.LoadUint64(&b.tail)
+	if uint64(n) > length {
+		discarded = int(length)
+		err = io.EOF
+	} else {
+		discarded = n
+	}
+
+	atomic.AddUint64(&b.tail, uint64(discarded))
+	if discarded > 0 {
+		notify(b.spaceReady)
+	}
+	return
+}
+
+// WritableSlices returns, without advancing head, the buffer's free space as two slices: the
+// contiguous run starting at the current write position, and—if the free space wraps around the
+// end of Buf—the remainder continuing from the start of Buf. second is nil if no wraparound is
+// involved.
+//
+// A caller may write directly into these slices (e.g. via syscall.Read or net.Conn.Read) and
+// then call Commit with however many bytes were actually filled in. The returned slices alias
+// Buf directly and are invalidated by the next Write or Commit.
+//
+// Only one goroutine should call WritableSlices (or Write/Commit) at a time—WritableSlices is
+// not thread safe (the write side and Read/WriteTo can be called concurrently however).
+func (b *Buffer) WritableSlices() (first, second []byte) {
+	head := atomic.LoadUint64(&b.head)
+	length := head - atomic.LoadUint64(&b.tail)
+	space := uint64(len(b.Buf)) - length
+	if space == 0 {
+		return nil, nil
+	}
+
+	bOffset := int(b.index(head))
+	end := bOffset + int(space)
+	if end > len(b.Buf) {
+		first = b.Buf[bOffset:]
+		second = b.Buf[:end-len(b.Buf)]
+	} else {
+		first = b.Buf[bOffset:end]
+	}
+	return
+}
+
+// Commit advances head by n, publishing n bytes that a caller has just written directly into
+// the slices returned by a prior call to WritableSlices. n must not exceed the total length of
+// those slices.
+//
+// Only one goroutine should call Commit (or Write/WritableSlices) at a time—Commit is not thread
+// safe (the write side and Read/WriteTo can be called concurrently however).
+func (b *Buffer) Commit(n int) {
+	if n <= 0 {
+		return
+	}
+	atomic.AddUint64(&b.head, uint64(n))
+	notify(b.dataReady)
+}
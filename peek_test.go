@@ -0,0 +1,73 @@
+package circular
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestCircularBuffer_PeekAndDiscard(t *testing.T) {
+	b := &Buffer{Buf: make([]byte, 10)}
+	_, _ = b.Write(make([]byte, 6))
+	_, _ = b.Read(make([]byte, 6))
+	_, _ = b.Write([]byte("abcdefghij")) // wraps: "abcd" at the end of Buf, then "efghij" from its start
+
+	first, second, err := b.Peek(10)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(append(append([]byte{}, first...), second...), []byte("abcdefghij")) {
+		t.Errorf("peeked incorrect bytes (first: %s, second: %s)", first, second)
+	}
+
+	// Peeking more than is available should return everything there is, plus io.EOF.
+	_, _, err = b.Peek(11)
+	if err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+
+	n, err := b.Discard(4)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if n != 4 {
+		t.Errorf("incorrect n (got: %d, expected: 4)", n)
+	}
+
+	out := make([]byte, 6)
+	_, _ = b.Read(out)
+	if !bytes.Equal(out, []byte("efghij")) {
+		t.Errorf("read incorrect bytes after Discard (read: %s, expected: efghij)", out)
+	}
+
+	n, err = b.Discard(1)
+	if n != 0 || err != io.EOF {
+		t.Errorf("expected 0, io.EOF discarding from empty buffer (got %d, %v)", n, err)
+	}
+}
+
+func TestCircularBuffer_WritableSlicesAndCommit(t *testing.T) {
+	b := &Buffer{Buf: make([]byte, 10)}
+	_, _ = b.Write(make([]byte, 8))
+	_, _ = b.Read(make([]byte, 8)) // head == tail == 8, so free space wraps around Buf
+
+	first, second := b.WritableSlices()
+	total := len(first) + len(second)
+	if total != b.Space() {
+		t.Errorf("writable slices don't cover all free space (got: %d, expected: %d)", total, b.Space())
+	}
+
+	n := copy(first, []byte("ABCDEF"))
+	n += copy(second, []byte("ABCDEF")[n:])
+	b.Commit(n)
+
+	if b.Len() != n {
+		t.Errorf("incorrect len after Commit (got: %d, expected: %d)", b.Len(), n)
+	}
+
+	out := make([]byte, n)
+	_, _ = b.Read(out)
+	if !bytes.Equal(out, []byte("ABCDEF")) {
+		t.Errorf("read incorrect bytes after Commit (read: %s, expected: ABCDEF)", out)
+	}
+}
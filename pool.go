@@ -0,0 +1,52 @@
+package circular
+
+import (
+	"fmt"
+	"sync"
+)
+
+// BufferPool recycles Buffers bucketed by capacity (rounded up to the next power of two), so
+// that high-throughput callers—per-connection ring buffers in a proxy, per-request scratch
+// rings—can avoid a fresh make([]byte, n) on every use. Each bucket is backed by its own
+// sync.Pool.
+//
+// The zero value is a ready-to-use, empty pool.
+type BufferPool struct {
+	pools sync.Map // map[int]*sync.Pool, keyed by bucket capacity
+}
+
+// Get returns a Buffer with capacity at least minCap, either recycled from the pool or newly
+// allocated via NewBufferPow2. The returned Buffer is always empty.
+func (p *BufferPool) Get(minCap int) *Buffer {
+	return p.poolFor(nextPow2(minCap)).Get().(*Buffer)
+}
+
+// Put returns buf to the pool for reuse by a future Get.
+//
+// Returns ErrBufferNotEmpty, and does not pool buf, if buf.Len() != 0—an unread buffer must not
+// be recycled, as that would leak its prior contents to whoever calls Get next. Callers that
+// want to pool a partially-read buffer should Reset it themselves first only once they're sure
+// discarding the unread bytes is safe.
+func (p *BufferPool) Put(buf *Buffer) error {
+	if buf.Len() != 0 {
+		return ErrBufferNotEmpty
+	}
+	buf.Reset()
+	p.poolFor(buf.Cap()).Put(buf)
+	return nil
+}
+
+// poolFor returns the sync.Pool for the given bucket capacity, creating it on first use.
+func (p *BufferPool) poolFor(cap int) *sync.Pool {
+	if v, ok := p.pools.Load(cap); ok {
+		return v.(*sync.Pool)
+	}
+	actual, _ := p.pools.LoadOrStore(cap, &sync.Pool{
+		New: func() interface{} { return NewBufferPow2(cap) },
+	})
+	return actual.(*sync.Pool)
+}
+
+// ErrBufferNotEmpty is the error returned by BufferPool.Put when given a Buffer that still has
+// unread bytes.
+var ErrBufferNotEmpty = fmt.Errorf("buffer not empty, cannot be pooled")
@@ -0,0 +1,33 @@
+package circular
+
+import "testing"
+
+func TestBufferPool_GetPut(t *testing.T) {
+	var pool BufferPool
+
+	b := pool.Get(100)
+	if b.Cap() != 128 {
+		t.Errorf("incorrect cap (got: %d, expected: 128)", b.Cap())
+	}
+	if b.Len() != 0 {
+		t.Errorf("buffer from pool should be empty, got len %d", b.Len())
+	}
+
+	_, _ = b.Write([]byte("hello"))
+	if err := pool.Put(b); err != ErrBufferNotEmpty {
+		t.Errorf("expected ErrBufferNotEmpty putting a non-empty buffer, got %v", err)
+	}
+
+	_, _ = b.Read(make([]byte, 5))
+	if err := pool.Put(b); err != nil {
+		t.Errorf("unexpected error putting an empty buffer: %v", err)
+	}
+
+	b2 := pool.Get(100)
+	if b2.Cap() != 128 {
+		t.Errorf("incorrect cap (got: %d, expected: 128)", b2.Cap())
+	}
+	if b2.Len() != 0 {
+		t.Errorf("buffer reused from pool should be empty, got len %d", b2.Len())
+	}
+}